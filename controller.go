@@ -0,0 +1,324 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	rotav1alpha1 "github.com/01akshay29/kubesecret_rota/pkg/apis/rota/v1alpha1"
+	versioned "github.com/01akshay29/kubesecret_rota/pkg/client/clientset/versioned"
+	externalversions "github.com/01akshay29/kubesecret_rota/pkg/client/informers/externalversions"
+	listers "github.com/01akshay29/kubesecret_rota/pkg/client/listers/rota/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// ResyncPeriod is how often the informer caches replay their contents
+	// through the event handlers, so expiry deadlines still fire even
+	// without a triggering Secret add/update.
+	ResyncPeriod = 10 * time.Minute
+
+	maxRetries = 5
+)
+
+// Controller reconciles Secrets carrying an expiry annotation. It replaces
+// the previous fixed-interval full-cluster poll with informer-driven,
+// per-secret reconciliation: a Secret add/update enqueues just that secret's
+// key, and a periodic resync re-enqueues everything so deadlines still fire
+// without a triggering event.
+type Controller struct {
+	clientset     *kubernetes.Clientset
+	dynamicClient dynamic.Interface
+	informers     informers.SharedInformerFactory
+	listers       *Listers
+	queue         workqueue.RateLimitingInterface
+	rotator       *Rotator
+
+	policyClientset versioned.Interface
+	policyInformers externalversions.SharedInformerFactory
+	policyLister    listers.SecretRotationPolicyLister
+
+	synced int32 // set to 1 once informer caches have synced; read via Ready
+
+	trackedMu sync.Mutex
+	tracked   map[string]map[string]bool // namespace -> secret name -> tracked
+}
+
+// NewController wires up informers for Secrets, the workload kinds that can
+// consume them, and SecretRotationPolicies, and returns a Controller ready
+// to Run.
+func NewController(clientset *kubernetes.Clientset, dynamicClient dynamic.Interface, policyClientset versioned.Interface, rotator *Rotator) *Controller {
+	factory := informers.NewSharedInformerFactory(clientset, ResyncPeriod)
+	policyFactory := externalversions.NewSharedInformerFactory(policyClientset, ResyncPeriod)
+
+	c := &Controller{
+		clientset:       clientset,
+		dynamicClient:   dynamicClient,
+		informers:       factory,
+		queue:           workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		rotator:         rotator,
+		policyClientset: policyClientset,
+		policyInformers: policyFactory,
+		policyLister:    policyFactory.Rota().V1alpha1().SecretRotationPolicies().Lister(),
+		tracked:         map[string]map[string]bool{},
+	}
+
+	factory.Core().V1().Secrets().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueue,
+		UpdateFunc: func(_, newObj interface{}) { c.enqueue(newObj) },
+	})
+
+	// These informers populate shared caches; all but the Deployments cache
+	// are consulted during reconciliation via c.listers (podOrSAUsesSecret
+	// and friends), and no lister reads the Deployments cache. They don't
+	// carry an expiry annotation themselves so they don't enqueue
+	// reconciles directly.
+	factory.Core().V1().Pods().Informer()
+	factory.Core().V1().ServiceAccounts().Informer()
+	factory.Apps().V1().ReplicaSets().Informer()
+	factory.Apps().V1().DaemonSets().Informer()
+	factory.Apps().V1().Deployments().Informer()
+	factory.Apps().V1().StatefulSets().Informer()
+	factory.Batch().V1().Jobs().Informer()
+	factory.Batch().V1().CronJobs().Informer()
+
+	c.listers = &Listers{
+		Pods:            factory.Core().V1().Pods().Lister(),
+		ReplicaSets:     factory.Apps().V1().ReplicaSets().Lister(),
+		DaemonSets:      factory.Apps().V1().DaemonSets().Lister(),
+		StatefulSets:    factory.Apps().V1().StatefulSets().Lister(),
+		Jobs:            factory.Batch().V1().Jobs().Lister(),
+		CronJobs:        factory.Batch().V1().CronJobs().Lister(),
+		ServiceAccounts: factory.Core().V1().ServiceAccounts().Lister(),
+	}
+
+	// SecretRotationPolicies are consulted by name at reconcile time, so
+	// this informer only needs to populate the shared cache behind
+	// policyLister.
+	policyFactory.Rota().V1alpha1().SecretRotationPolicies().Informer()
+
+	return c
+}
+
+func (c *Controller) enqueue(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	c.queue.Add(key)
+}
+
+// Run starts the informers, waits for their caches to sync, and runs the
+// given number of worker goroutines until ctx is cancelled.
+func (c *Controller) Run(ctx context.Context, workers int) error {
+	defer runtime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	c.informers.Start(ctx.Done())
+	c.policyInformers.Start(ctx.Done())
+
+	for informerType, synced := range c.informers.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			return fmt.Errorf("failed to sync informer cache for %v", informerType)
+		}
+	}
+	for informerType, synced := range c.policyInformers.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			return fmt.Errorf("failed to sync informer cache for %v", informerType)
+		}
+	}
+	atomic.StoreInt32(&c.synced, 1)
+
+	for i := 0; i < workers; i++ {
+		go wait.Until(func() { c.runWorker(ctx) }, time.Second, ctx.Done())
+	}
+
+	<-ctx.Done()
+	return nil
+}
+
+// Ready reports whether the controller's informer caches have synced, i.e.
+// it's safe to serve readiness probes as healthy.
+func (c *Controller) Ready() bool {
+	return atomic.LoadInt32(&c.synced) == 1
+}
+
+func (c *Controller) runWorker(ctx context.Context) {
+	for c.processNextItem(ctx) {
+	}
+}
+
+func (c *Controller) processNextItem(ctx context.Context) bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	err := c.reconcile(ctx, key.(string))
+	if err == nil {
+		c.queue.Forget(key)
+		return true
+	}
+
+	if c.queue.NumRequeues(key) < maxRetries {
+		klog.ErrorS(err, "Error reconciling, retrying", "key", key)
+		c.queue.AddRateLimited(key)
+		return true
+	}
+
+	klog.ErrorS(err, "Dropping key out of queue", "key", key)
+	c.queue.Forget(key)
+	runtime.HandleError(err)
+	return true
+}
+
+// reconcile fetches the secret named by key and, if it carries an expiry
+// annotation, reports the workloads that consume it and rotates it if due.
+func (c *Controller) reconcile(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	secret, err := c.clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		c.untrack(namespace, name)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get secret %s: %w", key, err)
+	}
+
+	expiryRaw, ok := secret.Annotations[ExpiryAnnotationKey]
+	if !ok {
+		c.untrack(namespace, name)
+		return nil
+	}
+
+	expiry, err := time.Parse(time.RFC3339, expiryRaw)
+	if err != nil {
+		klog.ErrorS(err, "Invalid expiry annotation", "namespace", namespace, "name", name, "expiry", expiryRaw)
+		return nil
+	}
+
+	klog.InfoS("Tracking secret expiry", "namespace", secret.Namespace, "name", secret.Name, "expiry", expiryRaw)
+	c.track(namespace, name)
+	secretExpirySecondsGauge.WithLabelValues(namespace, name).Set(time.Until(expiry).Seconds())
+
+	consumers, err := printResourcesUsingSecret(c.listers, c.dynamicClient, *secret)
+	if err != nil {
+		klog.ErrorS(err, "Failed to list resources using secret", "namespace", namespace, "name", name)
+	}
+
+	policy, err := resolvePolicy(c.policyLister, namespace, secret.Labels)
+	if err != nil {
+		klog.ErrorS(err, "Failed to resolve SecretRotationPolicy", "namespace", namespace, "name", name)
+	}
+
+	rotator, strategy, webhook, err := c.rotatorFor(policy)
+	if err != nil {
+		klog.ErrorS(err, "Failed to build rotator from SecretRotationPolicy", "namespace", namespace, "name", policy.Name)
+		return nil
+	}
+
+	if rotator == nil || consumers == nil {
+		return nil
+	}
+
+	attempted, rotateErr := rotateIfNeeded(rotator, strategy, *secret, consumers)
+	if rotateErr != nil {
+		klog.ErrorS(rotateErr, "Failed to rotate secret", "namespace", namespace, "name", name)
+	}
+	if !attempted {
+		return nil
+	}
+
+	// Under RestartStrategyNone nothing is restarted, so no workload is
+	// reported as affected.
+	var affected []string
+	if strategy != rotav1alpha1.RestartStrategyNone {
+		affected = consumers.restartableWorkloads(namespace)
+	}
+
+	if webhook != "" {
+		notifyWebhook(webhook, NotifyPayload{
+			Namespace:         namespace,
+			Name:              name,
+			RotatedAt:         time.Now(),
+			Success:           rotateErr == nil,
+			Error:             errString(rotateErr),
+			AffectedWorkloads: affected,
+		})
+	}
+
+	if policy != nil && rotateErr == nil {
+		nextRotation := time.Now().Add(DefaultSecretTTL - rotator.RotateBefore)
+		if err := updatePolicyStatus(ctx, c.policyClientset, policy, time.Now(), nextRotation, affected); err != nil {
+			klog.ErrorS(err, "Failed to update SecretRotationPolicy status", "namespace", namespace, "name", policy.Name)
+		}
+	}
+
+	return nil
+}
+
+// rotatorFor returns the Rotator, RestartStrategy, and notify webhook to use
+// for a Secret matched by policy. When policy is nil, it falls back to the
+// controller's flag-configured rotator with a rolling restart strategy and
+// no webhook.
+func (c *Controller) rotatorFor(policy *rotav1alpha1.SecretRotationPolicy) (*Rotator, rotav1alpha1.RestartStrategy, string, error) {
+	if policy == nil {
+		return c.rotator, rotav1alpha1.RestartStrategyRolling, "", nil
+	}
+
+	generator, err := buildGenerator(policy.Spec.Generator)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("policy %s/%s: %w", policy.Namespace, policy.Name, err)
+	}
+
+	strategy := policy.Spec.RestartStrategy
+	if strategy == "" {
+		strategy = rotav1alpha1.RestartStrategyRolling
+	}
+
+	rotator := NewRotator(c.clientset, generator, policy.Spec.RotateBefore.Duration)
+	return rotator, strategy, policy.Spec.Notify.Webhook, nil
+}
+
+// track records namespace/name as a tracked secret and refreshes the
+// per-namespace tracked-secret gauge.
+func (c *Controller) track(namespace, name string) {
+	c.trackedMu.Lock()
+	defer c.trackedMu.Unlock()
+
+	if c.tracked[namespace] == nil {
+		c.tracked[namespace] = map[string]bool{}
+	}
+	c.tracked[namespace][name] = true
+	secretsTrackedGauge.WithLabelValues(namespace).Set(float64(len(c.tracked[namespace])))
+}
+
+// untrack removes namespace/name from the tracked set, refreshes the
+// per-namespace tracked-secret gauge, and drops its expiry gauge.
+func (c *Controller) untrack(namespace, name string) {
+	c.trackedMu.Lock()
+	defer c.trackedMu.Unlock()
+
+	if c.tracked[namespace] != nil {
+		delete(c.tracked[namespace], name)
+		secretsTrackedGauge.WithLabelValues(namespace).Set(float64(len(c.tracked[namespace])))
+	}
+	secretExpirySecondsGauge.DeleteLabelValues(namespace, name)
+}
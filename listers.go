@@ -0,0 +1,20 @@
+package main
+
+import (
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	batchlisters "k8s.io/client-go/listers/batch/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+)
+
+// Listers holds the typed listers backed by Controller's shared informer
+// caches, so reconciliation reads workload state from cache instead of
+// hitting the API server on every Secret event.
+type Listers struct {
+	Pods            corelisters.PodLister
+	ReplicaSets     appslisters.ReplicaSetLister
+	DaemonSets      appslisters.DaemonSetLister
+	StatefulSets    appslisters.StatefulSetLister
+	Jobs            batchlisters.JobLister
+	CronJobs        batchlisters.CronJobLister
+	ServiceAccounts corelisters.ServiceAccountLister
+}
@@ -2,99 +2,216 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"os"
-	"strings"
 	"time"
 
+	rotav1alpha1 "github.com/01akshay29/kubesecret_rota/pkg/apis/rota/v1alpha1"
+	versioned "github.com/01akshay29/kubesecret_rota/pkg/client/clientset/versioned"
 	v1 "k8s.io/api/core/v1"
-	appsv1 "k8s.io/api/apps/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/dynamic"
 	kubernetes "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
 )
 
 const (
 	ExpiryAnnotationKey = "secret-expiry"
-	PollInterval        = 15 * time.Second // shorter interval for easier testing
+
+	// DefaultSecretTTL is the lifetime assigned to a Secret's expiry
+	// annotation after it is rotated.
+	DefaultSecretTTL = 30 * 24 * time.Hour
+
+	workers = 2
 )
 
 func main() {
-	fmt.Println("Secret checker started...")
+	klog.InitFlags(nil)
+	rotateBefore := flag.Duration("rotate-before", 0, "rotate secrets whose expiry falls within this duration of now (0 disables rotation of secrets that no SecretRotationPolicy matches)")
+	metricsAddr := flag.String("metrics-addr", ":8080", "address to serve /metrics, /healthz, and /readyz on")
+	flag.Parse()
+	defer klog.Flush()
+
+	klog.InfoS("Secret checker started")
 
-	clientset, err := getClient()
+	clientset, dynamicClient, policyClientset, err := getClient()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to create Kubernetes client: %v\n", err)
+		klog.ErrorS(err, "Failed to create Kubernetes client")
 		os.Exit(1)
 	}
 
-	ticker := time.NewTicker(PollInterval)
-	defer ticker.Stop()
+	var rotator *Rotator
+	if *rotateBefore > 0 {
+		rotator = NewRotator(clientset, RandomGenerator{}, *rotateBefore)
+	}
 
-	for {
-		select {
-		case <-ticker.C:
-			err := checkSecrets(clientset)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error checking secrets: %v\n", err)
-			}
-		}
+	controller := NewController(clientset, dynamicClient, policyClientset, rotator)
+
+	healthServer := newHealthServer(*metricsAddr, controller)
+	go runHealthServer(healthServer)
+
+	if err := controller.Run(context.Background(), workers); err != nil {
+		klog.ErrorS(err, "Controller exited")
+		os.Exit(1)
 	}
 }
 
-func getClient() (*kubernetes.Clientset, error) {
+func getClient() (*kubernetes.Clientset, dynamic.Interface, versioned.Interface, error) {
 	config, err := rest.InClusterConfig()
 	if err != nil {
 		kubeconfig := os.Getenv("KUBECONFIG")
 		if kubeconfig == "" {
-			return nil, fmt.Errorf("cannot create in-cluster config and no KUBECONFIG provided")
+			return nil, nil, nil, fmt.Errorf("cannot create in-cluster config and no KUBECONFIG provided")
 		}
 		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, err
 		}
 	}
-	return kubernetes.NewForConfig(config)
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	policyClientset, err := versioned.NewForConfig(config)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return clientset, dynamicClient, policyClientset, nil
 }
 
-func checkSecrets(clientset *kubernetes.Clientset) error {
-	secrets, err := clientset.CoreV1().Secrets("").List(context.Background(), metav1.ListOptions{})
+// rotateIfNeeded rotates secret if it falls within the rotator's threshold,
+// then rolls the Deployments, DaemonSets and StatefulSets that were found to
+// consume it according to strategy. Jobs and CronJobs are reported but never
+// rolled: a restart annotation has no meaning for a one-shot Pod template.
+// ReplicaSets and the owner kinds in SecretConsumers.Other are not rolled
+// either. attempted reports
+// whether rotation was due, regardless of whether it ultimately succeeded.
+func rotateIfNeeded(rotator *Rotator, strategy rotav1alpha1.RestartStrategy, secret v1.Secret, consumers *SecretConsumers) (attempted bool, err error) {
+	due, _, err := rotator.ShouldRotate(secret)
 	if err != nil {
-		return fmt.Errorf("failed to list secrets: %w", err)
+		return false, err
+	}
+	if !due {
+		return false, nil
 	}
 
-	for _, secret := range secrets.Items {
-		expiryRaw, ok := secret.Annotations[ExpiryAnnotationKey]
-		if !ok {
-			continue
-		}
+	ctx := context.Background()
 
-		// Print in previous format
-		fmt.Printf("Secret: %s/%s, Expiry: %s\n", secret.Namespace, secret.Name, expiryRaw)
+	klog.InfoS("Rotating secret", "namespace", secret.Namespace, "name", secret.Name)
+	_, rotateErr := rotator.Rotate(ctx, secret, DefaultSecretTTL)
+	recordRotation(secret.Namespace, secret.Name, rotateErr)
+	if rotateErr != nil {
+		return true, rotateErr
+	}
 
-		err := printResourcesUsingSecret(clientset, secret)
-		if err != nil {
-			fmt.Printf("  Error listing resources using secret: %v\n", err)
-		}
-		fmt.Println()
+	return true, rotator.RestartWorkloads(ctx, secret.Namespace, strategy, consumers.Deployments, consumers.DaemonSets, consumers.StatefulSets)
+}
+
+// SecretConsumers records, by kind, the workloads found to reference a
+// Secret either directly or through their ServiceAccount.
+type SecretConsumers struct {
+	Deployments  map[string]bool
+	ReplicaSets  map[string]bool
+	DaemonSets   map[string]bool
+	StatefulSets map[string]bool
+	Jobs         map[string]bool
+	CronJobs     map[string]bool
+
+	// Other holds owners resolved by resolveTopLevelOwner that aren't one
+	// of the kinds above, keyed by Kind (e.g. "Rollout", "DeploymentConfig").
+	Other map[string]map[string]bool
+}
+
+func newSecretConsumers() *SecretConsumers {
+	return &SecretConsumers{
+		Deployments:  map[string]bool{},
+		ReplicaSets:  map[string]bool{},
+		DaemonSets:   map[string]bool{},
+		StatefulSets: map[string]bool{},
+		Jobs:         map[string]bool{},
+		CronJobs:     map[string]bool{},
+		Other:        map[string]map[string]bool{},
 	}
+}
 
-	return nil
+func (c *SecretConsumers) empty() bool {
+	return len(c.Deployments) == 0 && len(c.ReplicaSets) == 0 && len(c.DaemonSets) == 0 &&
+		len(c.StatefulSets) == 0 && len(c.Jobs) == 0 && len(c.CronJobs) == 0 && len(c.Other) == 0
 }
 
-func printResourcesUsingSecret(clientset *kubernetes.Clientset, secret v1.Secret) error {
-	deployments := map[string]bool{}
-	replicaSets := map[string]bool{}
-	daemonSets := map[string]bool{}
+// restartableWorkloads formats the workloads RestartWorkloads can roll
+// (Deployments, DaemonSets, StatefulSets) as "<kind>/<namespace>/<name>",
+// for reporting in a SecretRotationPolicy's status.
+func (c *SecretConsumers) restartableWorkloads(namespace string) []string {
+	var names []string
+	for name := range c.Deployments {
+		names = append(names, fmt.Sprintf("Deployment/%s/%s", namespace, name))
+	}
+	for name := range c.DaemonSets {
+		names = append(names, fmt.Sprintf("DaemonSet/%s/%s", namespace, name))
+	}
+	for name := range c.StatefulSets {
+		names = append(names, fmt.Sprintf("StatefulSet/%s/%s", namespace, name))
+	}
+	return names
+}
+
+// recordOwner attributes a ReplicaSet to its true owning workload by walking
+// its OwnerReferences chain, rather than guessing from its name.
+func (c *SecretConsumers) recordOwner(ctx context.Context, dynamicClient dynamic.Interface, namespace, rsName string) {
+	top, err := resolveTopLevelOwner(ctx, dynamicClient, OwnerRef{Kind: "ReplicaSet", Namespace: namespace, Name: rsName})
+	if err != nil {
+		klog.ErrorS(err, "Failed to resolve owner of ReplicaSet", "namespace", namespace, "name", rsName)
+		return
+	}
+
+	switch top.Kind {
+	case "Deployment":
+		c.Deployments[top.Name] = true
+	case "StatefulSet":
+		c.StatefulSets[top.Name] = true
+	case "DaemonSet":
+		c.DaemonSets[top.Name] = true
+	case "ReplicaSet":
+		// No controller owner found; the ReplicaSet itself is already
+		// recorded by the caller.
+	default:
+		if c.Other[top.Kind] == nil {
+			c.Other[top.Kind] = map[string]bool{}
+		}
+		c.Other[top.Kind][top.Name] = true
+	}
+}
 
-	pods, err := clientset.CoreV1().Pods(secret.Namespace).List(context.Background(), metav1.ListOptions{})
+// printResourcesUsingSecret finds the workloads that reference secret,
+// reading Pods and their owning workloads through the shared informer
+// caches in listers rather than hitting the API server on every reconcile.
+// The one exception is recordOwner's owner walk: it issues a live
+// dynamicClient.Get per matched ReplicaSet to resolve its controller owner
+// (including the common Deployment-owned case), since arbitrary owner kinds
+// aren't covered by a typed informer.
+func printResourcesUsingSecret(listers *Listers, dynamicClient dynamic.Interface, secret v1.Secret) (*SecretConsumers, error) {
+	ctx := context.Background()
+	consumers := newSecretConsumers()
+
+	pods, err := listers.Pods.Pods(secret.Namespace).List(labels.Everything())
 	if err != nil {
-		return fmt.Errorf("error listing pods: %w", err)
+		return nil, fmt.Errorf("error listing pods: %w", err)
 	}
 
-	for _, pod := range pods.Items {
-		if podUsesSecret(&pod, secret.Name) {
+	for _, pod := range pods {
+		if podOrSAUsesSecret(listers, &pod.Spec, secret.Namespace, secret.Name) {
 			for _, owner := range pod.OwnerReferences {
 				if owner.Controller == nil || !*owner.Controller {
 					continue
@@ -102,93 +219,162 @@ func printResourcesUsingSecret(clientset *kubernetes.Clientset, secret v1.Secret
 
 				switch owner.Kind {
 				case "ReplicaSet":
-					rsName := owner.Name
-					replicaSets[rsName] = true
-					depName := extractDeploymentName(rsName)
-					if depName != "" {
-						deployments[depName] = true
-					}
+					consumers.ReplicaSets[owner.Name] = true
+					consumers.recordOwner(ctx, dynamicClient, secret.Namespace, owner.Name)
 				case "DaemonSet":
-					daemonSets[owner.Name] = true
+					consumers.DaemonSets[owner.Name] = true
 				case "Deployment":
-					deployments[owner.Name] = true
+					consumers.Deployments[owner.Name] = true
+				case "StatefulSet":
+					consumers.StatefulSets[owner.Name] = true
+				case "Job":
+					consumers.Jobs[owner.Name] = true
 				}
 			}
 		}
 	}
 
-	rsList, err := clientset.AppsV1().ReplicaSets(secret.Namespace).List(context.Background(), metav1.ListOptions{})
+	rsList, err := listers.ReplicaSets.ReplicaSets(secret.Namespace).List(labels.Everything())
 	if err == nil {
-		for _, rs := range rsList.Items {
-			var _ appsv1.ReplicaSet = rs
-			if podTemplateUsesSecret(rs.Spec.Template, secret.Name) {
-				replicaSets[rs.Name] = true
-				depName := extractDeploymentName(rs.Name)
-				if depName != "" {
-					deployments[depName] = true
-				}
+		for _, rs := range rsList {
+			if podOrSAUsesSecret(listers, &rs.Spec.Template.Spec, secret.Namespace, secret.Name) {
+				consumers.ReplicaSets[rs.Name] = true
+				consumers.recordOwner(ctx, dynamicClient, secret.Namespace, rs.Name)
 			}
 		}
 	}
 
-	dsList, err := clientset.AppsV1().DaemonSets(secret.Namespace).List(context.Background(), metav1.ListOptions{})
+	dsList, err := listers.DaemonSets.DaemonSets(secret.Namespace).List(labels.Everything())
 	if err == nil {
-		for _, ds := range dsList.Items {
-			var _ appsv1.DaemonSet = ds
-			if podTemplateUsesSecret(ds.Spec.Template, secret.Name) {
-				daemonSets[ds.Name] = true
+		for _, ds := range dsList {
+			if podOrSAUsesSecret(listers, &ds.Spec.Template.Spec, secret.Namespace, secret.Name) {
+				consumers.DaemonSets[ds.Name] = true
 			}
 		}
 	}
 
-	if len(deployments) == 0 && len(replicaSets) == 0 && len(daemonSets) == 0 {
-		fmt.Println("  No deployments, replicasets, or daemonsets use this secret.")
-	} else {
-		if len(deployments) > 0 {
-			fmt.Println("  Used by Deployments:")
-			for dep := range deployments {
-				fmt.Printf("    - %s/%s\n", secret.Namespace, dep)
+	ssList, err := listers.StatefulSets.StatefulSets(secret.Namespace).List(labels.Everything())
+	if err == nil {
+		for _, ss := range ssList {
+			if podOrSAUsesSecret(listers, &ss.Spec.Template.Spec, secret.Namespace, secret.Name) {
+				consumers.StatefulSets[ss.Name] = true
 			}
 		}
-		if len(replicaSets) > 0 {
-			fmt.Println("  Used by ReplicaSets:")
-			for rs := range replicaSets {
-				fmt.Printf("    - %s/%s\n", secret.Namespace, rs)
+	}
+
+	jobList, err := listers.Jobs.Jobs(secret.Namespace).List(labels.Everything())
+	if err == nil {
+		for _, job := range jobList {
+			if podOrSAUsesSecret(listers, &job.Spec.Template.Spec, secret.Namespace, secret.Name) {
+				consumers.Jobs[job.Name] = true
 			}
 		}
-		if len(daemonSets) > 0 {
-			fmt.Println("  Used by DaemonSets:")
-			for ds := range daemonSets {
-				fmt.Printf("    - %s/%s\n", secret.Namespace, ds)
+	}
+
+	cronJobList, err := listers.CronJobs.CronJobs(secret.Namespace).List(labels.Everything())
+	if err == nil {
+		for _, cronJob := range cronJobList {
+			spec := cronJob.Spec.JobTemplate.Spec.Template.Spec
+			if podOrSAUsesSecret(listers, &spec, secret.Namespace, secret.Name) {
+				consumers.CronJobs[cronJob.Name] = true
 			}
 		}
 	}
 
-	return nil
+	if consumers.empty() {
+		klog.InfoS("No workloads reference secret", "namespace", secret.Namespace, "name", secret.Name)
+	} else {
+		printConsumerKind(secret.Namespace, "Deployments", consumers.Deployments)
+		printConsumerKind(secret.Namespace, "ReplicaSets", consumers.ReplicaSets)
+		printConsumerKind(secret.Namespace, "DaemonSets", consumers.DaemonSets)
+		printConsumerKind(secret.Namespace, "StatefulSets", consumers.StatefulSets)
+		printConsumerKind(secret.Namespace, "Jobs", consumers.Jobs)
+		printConsumerKind(secret.Namespace, "CronJobs", consumers.CronJobs)
+		for kind, names := range consumers.Other {
+			printConsumerKind(secret.Namespace, kind+"s", names)
+		}
+	}
+
+	return consumers, nil
+}
+
+func printConsumerKind(namespace, kind string, names map[string]bool) {
+	for name := range names {
+		klog.InfoS("Secret referenced by workload", "kind", kind, "namespace", namespace, "name", name)
+	}
 }
 
-func podUsesSecret(pod *v1.Pod, secretName string) bool {
-	for _, vol := range pod.Spec.Volumes {
+// podSpecReferencesSecret reports whether spec references the named Secret,
+// via a Secret volume, a projected Secret source, an imagePullSecret, or an
+// env/envFrom secretRef on any container.
+func podSpecReferencesSecret(spec *v1.PodSpec, secretName string) bool {
+	for _, vol := range spec.Volumes {
 		if vol.Secret != nil && vol.Secret.SecretName == secretName {
 			return true
 		}
+		if vol.Projected != nil {
+			for _, source := range vol.Projected.Sources {
+				if source.Secret != nil && source.Secret.Name == secretName {
+					return true
+				}
+			}
+		}
 	}
-	return false
-}
 
-func podTemplateUsesSecret(podTemplate v1.PodTemplateSpec, secretName string) bool {
-	for _, vol := range podTemplate.Spec.Volumes {
-		if vol.Secret != nil && vol.Secret.SecretName == secretName {
+	for _, ref := range spec.ImagePullSecrets {
+		if ref.Name == secretName {
 			return true
 		}
 	}
+
+	for _, container := range append(append([]v1.Container{}, spec.InitContainers...), spec.Containers...) {
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil && env.ValueFrom.SecretKeyRef.Name == secretName {
+				return true
+			}
+		}
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil && envFrom.SecretRef.Name == secretName {
+				return true
+			}
+		}
+	}
+
 	return false
 }
 
-func extractDeploymentName(rsName string) string {
-	parts := strings.Split(rsName, "-")
-	if len(parts) < 2 {
-		return ""
+// podOrSAUsesSecret reports whether spec references secretName directly, or
+// indirectly through the ServiceAccount it runs as (e.g. an SA token Secret
+// or an SA-level imagePullSecret).
+func podOrSAUsesSecret(listers *Listers, spec *v1.PodSpec, namespace, secretName string) bool {
+	if podSpecReferencesSecret(spec, secretName) {
+		return true
 	}
-	return strings.Join(parts[:len(parts)-1], "-")
+	return serviceAccountReferencesSecret(listers, namespace, spec.ServiceAccountName, secretName)
+}
+
+// serviceAccountReferencesSecret reports whether the named ServiceAccount
+// lists secretName among its .secrets or .imagePullSecrets.
+func serviceAccountReferencesSecret(listers *Listers, namespace, serviceAccountName, secretName string) bool {
+	if serviceAccountName == "" {
+		serviceAccountName = "default"
+	}
+
+	sa, err := listers.ServiceAccounts.ServiceAccounts(namespace).Get(serviceAccountName)
+	if err != nil {
+		return false
+	}
+
+	for _, ref := range sa.Secrets {
+		if ref.Name == secretName {
+			return true
+		}
+	}
+	for _, ref := range sa.ImagePullSecrets {
+		if ref.Name == secretName {
+			return true
+		}
+	}
+
+	return false
 }
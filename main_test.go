@@ -0,0 +1,93 @@
+package main
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestPodSpecReferencesSecret(t *testing.T) {
+	const secretName = "db-creds"
+
+	tests := []struct {
+		name string
+		spec v1.PodSpec
+		want bool
+	}{
+		{
+			name: "no reference",
+			spec: v1.PodSpec{},
+			want: false,
+		},
+		{
+			name: "secret volume",
+			spec: v1.PodSpec{
+				Volumes: []v1.Volume{
+					{VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: secretName}}},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "unrelated secret volume",
+			spec: v1.PodSpec{
+				Volumes: []v1.Volume{
+					{VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "other"}}},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "projected secret source",
+			spec: v1.PodSpec{
+				Volumes: []v1.Volume{
+					{VolumeSource: v1.VolumeSource{Projected: &v1.ProjectedVolumeSource{
+						Sources: []v1.VolumeProjection{
+							{Secret: &v1.SecretProjection{LocalObjectReference: v1.LocalObjectReference{Name: secretName}}},
+						},
+					}}},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "imagePullSecret",
+			spec: v1.PodSpec{
+				ImagePullSecrets: []v1.LocalObjectReference{{Name: secretName}},
+			},
+			want: true,
+		},
+		{
+			name: "container env secretKeyRef",
+			spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{Env: []v1.EnvVar{
+						{ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{Name: secretName},
+						}}},
+					}},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "initContainer envFrom secretRef",
+			spec: v1.PodSpec{
+				InitContainers: []v1.Container{
+					{EnvFrom: []v1.EnvFromSource{
+						{SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: secretName}}},
+					}},
+				},
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := podSpecReferencesSecret(&tt.spec, secretName); got != tt.want {
+				t.Errorf("podSpecReferencesSecret() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	secretsTrackedGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubesecret_rota_secrets_tracked",
+		Help: "Number of Secrets currently tracked for expiry, by namespace.",
+	}, []string{"namespace"})
+
+	secretExpirySecondsGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kubesecret_rota_secret_expiry_seconds",
+		Help: "Seconds until a tracked Secret's expiry annotation is reached; negative when overdue.",
+	}, []string{"namespace", "name"})
+
+	rotationsTotalCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubesecret_rota_rotations_total",
+		Help: "Total number of secret rotation attempts, by result.",
+	}, []string{"namespace", "name", "result"})
+
+	workloadRestartsTotalCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "kubesecret_rota_workload_restarts_total",
+		Help: "Total number of workload restarts (rolling or recreate) triggered by secret rotation.",
+	}, []string{"kind", "namespace", "name"})
+)
+
+const (
+	rotationResultSuccess = "success"
+	rotationResultError   = "error"
+)
+
+// recordRotation records the outcome of a single secret rotation attempt.
+func recordRotation(namespace, name string, err error) {
+	result := rotationResultSuccess
+	if err != nil {
+		result = rotationResultError
+	}
+	rotationsTotalCounter.WithLabelValues(namespace, name, result).Inc()
+}
+
+// recordWorkloadRestart records a single workload restart, rolling or
+// recreate, triggered by secret rotation.
+func recordWorkloadRestart(kind, namespace, name string) {
+	workloadRestartsTotalCounter.WithLabelValues(kind, namespace, name).Inc()
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// webhookTimeout bounds how long a single rotation notification is allowed
+// to take, so a policy pointing at an unresponsive webhook can't stall the
+// reconcile worker that delivers it.
+const webhookTimeout = 5 * time.Second
+
+// NotifyPayload is the JSON body posted to a SecretRotationPolicy's
+// notify.webhook after each rotation attempt.
+type NotifyPayload struct {
+	Namespace         string    `json:"namespace"`
+	Name              string    `json:"name"`
+	RotatedAt         time.Time `json:"rotatedAt"`
+	Success           bool      `json:"success"`
+	Error             string    `json:"error,omitempty"`
+	AffectedWorkloads []string  `json:"affectedWorkloads,omitempty"`
+}
+
+// notifyWebhook posts payload to url as JSON, subject to webhookTimeout.
+// url comes from a namespaced SecretRotationPolicy that any tenant able to
+// create one in their own namespace controls, so it's validated against
+// internal/link-local targets before any request is made. Delivery failures
+// are logged rather than returned: a broken or disallowed webhook shouldn't
+// fail reconciliation.
+func notifyWebhook(url string, payload NotifyPayload) {
+	if url == "" {
+		return
+	}
+
+	if err := validateWebhookURL(url); err != nil {
+		klog.ErrorS(err, "Refusing to deliver rotation notification", "url", url)
+		return
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		klog.ErrorS(err, "Failed to marshal rotation notification", "url", url)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		klog.ErrorS(err, "Failed to build rotation notification request", "url", url)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{
+		Timeout:       webhookTimeout,
+		CheckRedirect: rejectUnsafeRedirect,
+		Transport:     &http.Transport{DialContext: safeDialContext},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		klog.ErrorS(err, "Failed to deliver rotation notification", "url", url)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		klog.ErrorS(fmt.Errorf("webhook returned status %d", resp.StatusCode), "Rotation notification rejected", "url", url)
+	}
+}
+
+// validateWebhookURL rejects webhook targets that could be used to reach
+// internal or metadata services from the cluster-privileged controller:
+// non-HTTP(S) schemes, and hostnames resolving to loopback, link-local
+// (including the 169.254.169.254 cloud metadata address), or other private
+// addresses.
+func validateWebhookURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid webhook url: %w", err)
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("webhook url scheme %q is not allowed", parsed.Scheme)
+	}
+
+	host := parsed.Hostname()
+	if host == "" {
+		return fmt.Errorf("webhook url has no host")
+	}
+
+	ips, err := net.LookupIP(host)
+	if err != nil {
+		return fmt.Errorf("failed to resolve webhook host %q: %w", host, err)
+	}
+	for _, ip := range ips {
+		if isDisallowedWebhookIP(ip) {
+			return fmt.Errorf("webhook host %q resolves to disallowed address %s", host, ip)
+		}
+	}
+
+	return nil
+}
+
+func isDisallowedWebhookIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() || ip.IsPrivate()
+}
+
+// rejectUnsafeRedirect re-runs validateWebhookURL against a redirect target.
+// Without this, http.Client's default redirect policy follows up to 10
+// redirects without ever re-checking them, so a webhook could pass initial
+// validation and then 302 straight to a disallowed address.
+func rejectUnsafeRedirect(req *http.Request, via []*http.Request) error {
+	if err := validateWebhookURL(req.URL.String()); err != nil {
+		return fmt.Errorf("rejecting webhook redirect: %w", err)
+	}
+	return nil
+}
+
+// safeDialContext resolves addr's host itself and dials the validated IP
+// directly, instead of handing the hostname to the dialer. Validating a
+// hostname and then letting the transport re-resolve it at connect time
+// leaves a DNS-rebind window: the name could answer safely for
+// validateWebhookURL/rejectUnsafeRedirect and then resolve to a disallowed
+// address by the time the connection is actually made.
+func safeDialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webhook dial address %q: %w", addr, err)
+	}
+
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip", host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve webhook host %q: %w", host, err)
+	}
+
+	var dialer net.Dialer
+	var lastErr error
+	for _, ip := range ips {
+		if isDisallowedWebhookIP(ip) {
+			lastErr = fmt.Errorf("webhook host %q resolves to disallowed address %s", host, ip)
+			continue
+		}
+		conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return conn, nil
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no addresses found for webhook host %q", host)
+	}
+	return nil, lastErr
+}
+
+// errString returns err.Error(), or "" if err is nil.
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
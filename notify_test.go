@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+func TestValidateWebhookURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{name: "public https IP", url: "https://93.184.216.34/hook", wantErr: false},
+		{name: "public http IP", url: "http://93.184.216.34/hook", wantErr: false},
+		{name: "disallowed scheme", url: "ftp://93.184.216.34/hook", wantErr: true},
+		{name: "no scheme", url: "93.184.216.34/hook", wantErr: true},
+		{name: "loopback", url: "http://127.0.0.1/hook", wantErr: true},
+		{name: "cloud metadata address", url: "http://169.254.169.254/latest/meta-data", wantErr: true},
+		{name: "private network", url: "http://10.0.0.5/hook", wantErr: true},
+		{name: "unspecified", url: "http://0.0.0.0/hook", wantErr: true},
+		{name: "malformed url", url: "http://[::1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateWebhookURL(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateWebhookURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
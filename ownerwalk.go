@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// knownOwnerGVRs maps the Kinds resolveTopLevelOwner knows how to fetch via
+// the dynamic client to their GroupVersionResource. This lets the owner walk
+// follow through ReplicaSets without a typed clientset for every possible
+// workload API (Argo Rollouts, OpenShift DeploymentConfigs, ...).
+var knownOwnerGVRs = map[string]schema.GroupVersionResource{
+	"ReplicaSet":       {Group: "apps", Version: "v1", Resource: "replicasets"},
+	"Deployment":       {Group: "apps", Version: "v1", Resource: "deployments"},
+	"StatefulSet":      {Group: "apps", Version: "v1", Resource: "statefulsets"},
+	"DaemonSet":        {Group: "apps", Version: "v1", Resource: "daemonsets"},
+	"Rollout":          {Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"},
+	"DeploymentConfig": {Group: "apps.openshift.io", Version: "v1", Resource: "deploymentconfigs"},
+}
+
+// topLevelOwnerKinds are the kinds resolveTopLevelOwner treats as the true
+// owning workload, rather than an intermediate object to keep walking
+// through.
+var topLevelOwnerKinds = map[string]bool{
+	"Deployment":       true,
+	"StatefulSet":      true,
+	"DaemonSet":        true,
+	"Rollout":          true,
+	"DeploymentConfig": true,
+}
+
+const maxOwnerWalkDepth = 10
+
+// OwnerRef identifies a Kubernetes object by kind, namespace and name.
+type OwnerRef struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// resolveTopLevelOwner walks the OwnerReferences chain starting at start via
+// the dynamic client until it reaches a controller owner in
+// topLevelOwnerKinds, or it can no longer follow the chain (unknown kind, no
+// controller owner, or maxOwnerWalkDepth reached). It returns start unchanged
+// when no walk is possible, so callers can always attribute the object to
+// *something*.
+func resolveTopLevelOwner(ctx context.Context, dynamicClient dynamic.Interface, start OwnerRef) (OwnerRef, error) {
+	current := start
+
+	for depth := 0; depth < maxOwnerWalkDepth; depth++ {
+		if topLevelOwnerKinds[current.Kind] {
+			return current, nil
+		}
+
+		gvr, known := knownOwnerGVRs[current.Kind]
+		if !known {
+			return current, nil
+		}
+
+		obj, err := dynamicClient.Resource(gvr).Namespace(current.Namespace).Get(ctx, current.Name, metav1.GetOptions{})
+		if err != nil {
+			return current, fmt.Errorf("failed to get %s %s/%s during owner walk: %w", current.Kind, current.Namespace, current.Name, err)
+		}
+
+		owner := controllerOwner(obj.GetOwnerReferences())
+		if owner == nil {
+			return current, nil
+		}
+
+		current = OwnerRef{Kind: owner.Kind, Namespace: current.Namespace, Name: owner.Name}
+	}
+
+	return current, nil
+}
+
+func controllerOwner(refs []metav1.OwnerReference) *metav1.OwnerReference {
+	for i, ref := range refs {
+		if ref.Controller != nil && *ref.Controller {
+			return &refs[i]
+		}
+	}
+	return nil
+}
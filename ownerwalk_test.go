@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func truePtr() *bool {
+	b := true
+	return &b
+}
+
+func newUnstructured(apiVersion, kind, namespace, name string, owners ...metav1.OwnerReference) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	if len(owners) > 0 {
+		obj.SetOwnerReferences(owners)
+	}
+	return obj
+}
+
+func TestResolveTopLevelOwner(t *testing.T) {
+	const namespace = "default"
+
+	deployment := newUnstructured("apps/v1", "Deployment", namespace, "web")
+	replicaSet := newUnstructured("apps/v1", "ReplicaSet", namespace, "web-abc123", metav1.OwnerReference{
+		APIVersion: "apps/v1", Kind: "Deployment", Name: "web", Controller: truePtr(),
+	})
+	orphanReplicaSet := newUnstructured("apps/v1", "ReplicaSet", namespace, "orphan-xyz")
+	rolloutOwnedRS := newUnstructured("apps/v1", "ReplicaSet", namespace, "canary-def456", metav1.OwnerReference{
+		APIVersion: "argoproj.io/v1alpha1", Kind: "Rollout", Name: "canary", Controller: truePtr(),
+	})
+
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), deployment, replicaSet, orphanReplicaSet, rolloutOwnedRS)
+
+	tests := []struct {
+		name  string
+		start OwnerRef
+		want  OwnerRef
+	}{
+		{
+			name:  "replicaset owned by deployment resolves to deployment",
+			start: OwnerRef{Kind: "ReplicaSet", Namespace: namespace, Name: "web-abc123"},
+			want:  OwnerRef{Kind: "Deployment", Namespace: namespace, Name: "web"},
+		},
+		{
+			name:  "replicaset owned by rollout resolves to rollout",
+			start: OwnerRef{Kind: "ReplicaSet", Namespace: namespace, Name: "canary-def456"},
+			want:  OwnerRef{Kind: "Rollout", Namespace: namespace, Name: "canary"},
+		},
+		{
+			name:  "orphan replicaset resolves to itself",
+			start: OwnerRef{Kind: "ReplicaSet", Namespace: namespace, Name: "orphan-xyz"},
+			want:  OwnerRef{Kind: "ReplicaSet", Namespace: namespace, Name: "orphan-xyz"},
+		},
+		{
+			name:  "unknown kind resolves to itself without a lookup",
+			start: OwnerRef{Kind: "Pod", Namespace: namespace, Name: "standalone"},
+			want:  OwnerRef{Kind: "Pod", Namespace: namespace, Name: "standalone"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveTopLevelOwner(context.Background(), client, tt.start)
+			if err != nil {
+				t.Fatalf("resolveTopLevelOwner() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveTopLevelOwner() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
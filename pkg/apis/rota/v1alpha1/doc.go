@@ -0,0 +1,4 @@
+// Package v1alpha1 contains the v1alpha1 API types for the rota.kubesecret.io
+// group.
+// +k8s:deepcopy-gen=package
+package v1alpha1
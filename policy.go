@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	rotav1alpha1 "github.com/01akshay29/kubesecret_rota/pkg/apis/rota/v1alpha1"
+	versioned "github.com/01akshay29/kubesecret_rota/pkg/client/clientset/versioned"
+	listers "github.com/01akshay29/kubesecret_rota/pkg/client/listers/rota/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+)
+
+// resolvePolicy returns the SecretRotationPolicy in namespace whose selector
+// matches secretLabels, or nil if none do. If more than one matches, the
+// winner is chosen deterministically (oldest CreationTimestamp, then
+// lexicographically smallest name) rather than by informer list order,
+// which isn't stable across resyncs or restarts.
+func resolvePolicy(lister listers.SecretRotationPolicyLister, namespace string, secretLabels map[string]string) (*rotav1alpha1.SecretRotationPolicy, error) {
+	policies, err := lister.SecretRotationPolicies(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SecretRotationPolicies in %s: %w", namespace, err)
+	}
+
+	var candidates []*rotav1alpha1.SecretRotationPolicy
+	for _, policy := range policies {
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.Selector)
+		if err != nil {
+			klog.ErrorS(err, "Invalid selector on SecretRotationPolicy", "namespace", namespace, "name", policy.Name)
+			continue
+		}
+		if !selector.Matches(labels.Set(secretLabels)) {
+			continue
+		}
+		candidates = append(candidates, policy)
+	}
+
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	matched := candidates[0]
+	for _, policy := range candidates[1:] {
+		if policyPrecedes(policy, matched) {
+			matched = policy
+		}
+	}
+	for _, policy := range candidates {
+		if policy.Name != matched.Name {
+			klog.InfoS("Multiple SecretRotationPolicies match secret, ignoring one", "namespace", namespace, "kept", matched.Name, "ignored", policy.Name)
+		}
+	}
+
+	return matched, nil
+}
+
+// policyPrecedes reports whether a should be preferred over b when both
+// match the same secret: the older policy wins, ties broken by
+// lexicographically smaller name.
+func policyPrecedes(a, b *rotav1alpha1.SecretRotationPolicy) bool {
+	at, bt := a.CreationTimestamp.Time, b.CreationTimestamp.Time
+	if !at.Equal(bt) {
+		return at.Before(bt)
+	}
+	return a.Name < b.Name
+}
+
+// buildGenerator constructs the Generator described by spec.
+func buildGenerator(spec rotav1alpha1.GeneratorSpec) (Generator, error) {
+	switch spec.Type {
+	case rotav1alpha1.GeneratorRandom, "":
+		return RandomGenerator{}, nil
+	case rotav1alpha1.GeneratorBcrypt:
+		return BcryptGenerator{}, nil
+	default:
+		return nil, fmt.Errorf("unknown generator type %q", spec.Type)
+	}
+}
+
+// updatePolicyStatus records the outcome of reconciling a Secret against
+// policy: when it was last rotated, when it's next due, and which workloads
+// were restarted as a result.
+func updatePolicyStatus(ctx context.Context, client versioned.Interface, policy *rotav1alpha1.SecretRotationPolicy, lastRotation, nextRotation time.Time, affected []string) error {
+	updated := policy.DeepCopy()
+
+	last := metav1.NewTime(lastRotation)
+	next := metav1.NewTime(nextRotation)
+	updated.Status.LastRotationTime = &last
+	updated.Status.NextRotationTime = &next
+	updated.Status.AffectedWorkloads = affected
+
+	_, err := client.RotaV1alpha1().SecretRotationPolicies(policy.Namespace).UpdateStatus(ctx, updated, metav1.UpdateOptions{})
+	return err
+}
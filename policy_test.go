@@ -0,0 +1,122 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	rotav1alpha1 "github.com/01akshay29/kubesecret_rota/pkg/apis/rota/v1alpha1"
+	listersv1alpha1 "github.com/01akshay29/kubesecret_rota/pkg/client/listers/rota/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newPolicyLister(t *testing.T, policies ...*rotav1alpha1.SecretRotationPolicy) listersv1alpha1.SecretRotationPolicyLister {
+	t.Helper()
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, policy := range policies {
+		if err := indexer.Add(policy); err != nil {
+			t.Fatalf("failed to seed indexer: %v", err)
+		}
+	}
+	return listersv1alpha1.NewSecretRotationPolicyLister(indexer)
+}
+
+func policyMatchingAll(namespace, name string, created time.Time) *rotav1alpha1.SecretRotationPolicy {
+	return &rotav1alpha1.SecretRotationPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         namespace,
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: rotav1alpha1.SecretRotationPolicySpec{
+			Selector: metav1.LabelSelector{}, // empty selector matches everything
+		},
+	}
+}
+
+func TestResolvePolicy(t *testing.T) {
+	now := time.Now()
+
+	t.Run("no match returns nil", func(t *testing.T) {
+		lister := newPolicyLister(t)
+		got, err := resolvePolicy(lister, "default", map[string]string{"app": "web"})
+		if err != nil {
+			t.Fatalf("resolvePolicy() error = %v", err)
+		}
+		if got != nil {
+			t.Errorf("resolvePolicy() = %v, want nil", got)
+		}
+	})
+
+	t.Run("single match wins", func(t *testing.T) {
+		policy := policyMatchingAll("default", "only", now)
+		lister := newPolicyLister(t, policy)
+		got, err := resolvePolicy(lister, "default", map[string]string{"app": "web"})
+		if err != nil {
+			t.Fatalf("resolvePolicy() error = %v", err)
+		}
+		if got == nil || got.Name != "only" {
+			t.Errorf("resolvePolicy() = %v, want policy %q", got, "only")
+		}
+	})
+
+	t.Run("conflicting matches prefer oldest, independent of list order", func(t *testing.T) {
+		older := policyMatchingAll("default", "zz-older", now)
+		newer := policyMatchingAll("default", "aa-newer", now.Add(time.Hour))
+
+		// Seed in an order that would pick the wrong winner if resolvePolicy
+		// fell back to cache-iteration order instead of CreationTimestamp.
+		lister := newPolicyLister(t, newer, older)
+		got, err := resolvePolicy(lister, "default", map[string]string{"app": "web"})
+		if err != nil {
+			t.Fatalf("resolvePolicy() error = %v", err)
+		}
+		if got == nil || got.Name != "zz-older" {
+			t.Errorf("resolvePolicy() = %v, want the older policy %q", got, "zz-older")
+		}
+	})
+
+	t.Run("equal timestamps break tie on name", func(t *testing.T) {
+		a := policyMatchingAll("default", "bbb", now)
+		b := policyMatchingAll("default", "aaa", now)
+
+		lister := newPolicyLister(t, a, b)
+		got, err := resolvePolicy(lister, "default", map[string]string{"app": "web"})
+		if err != nil {
+			t.Fatalf("resolvePolicy() error = %v", err)
+		}
+		if got == nil || got.Name != "aaa" {
+			t.Errorf("resolvePolicy() = %v, want the lexicographically smaller name %q", got, "aaa")
+		}
+	})
+}
+
+func TestBuildGenerator(t *testing.T) {
+	tests := []struct {
+		name     string
+		genType  rotav1alpha1.GeneratorType
+		wantType Generator
+		wantErr  bool
+	}{
+		{name: "random", genType: rotav1alpha1.GeneratorRandom, wantType: RandomGenerator{}},
+		{name: "empty defaults to random", genType: "", wantType: RandomGenerator{}},
+		{name: "bcrypt", genType: rotav1alpha1.GeneratorBcrypt, wantType: BcryptGenerator{}},
+		{name: "unknown type errors", genType: "externalRef", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildGenerator(rotav1alpha1.GeneratorSpec{Type: tt.genType})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("buildGenerator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.wantType {
+				t.Errorf("buildGenerator() = %#v, want %#v", got, tt.wantType)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	rotav1alpha1 "github.com/01akshay29/kubesecret_rota/pkg/apis/rota/v1alpha1"
+	"golang.org/x/crypto/bcrypt"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	RestartedAtAnnotationKey = "kubectl.kubernetes.io/restartedAt"
+
+	// DefaultRandomGeneratorLength is the byte length used by RandomGenerator
+	// when no explicit length is configured.
+	DefaultRandomGeneratorLength = 32
+)
+
+// Generator produces a fresh value for a secret key.
+type Generator interface {
+	Generate(key string) ([]byte, error)
+}
+
+// RandomGenerator produces a random, base64url-encoded string.
+type RandomGenerator struct {
+	Length int
+}
+
+func (g RandomGenerator) Generate(key string) ([]byte, error) {
+	length := g.Length
+	if length <= 0 {
+		length = DefaultRandomGeneratorLength
+	}
+
+	buf := make([]byte, length)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate random value for %q: %w", key, err)
+	}
+
+	return []byte(base64.RawURLEncoding.EncodeToString(buf)), nil
+}
+
+// BcryptGenerator generates a random password and returns its bcrypt hash,
+// for secrets that store a hashed credential rather than a raw one.
+type BcryptGenerator struct {
+	Cost int
+}
+
+func (g BcryptGenerator) Generate(key string) ([]byte, error) {
+	cost := g.Cost
+	if cost <= 0 {
+		cost = bcrypt.DefaultCost
+	}
+
+	raw, err := (RandomGenerator{}).Generate(key)
+	if err != nil {
+		return nil, err
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword(raw, cost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bcrypt-hash new value for %q: %w", key, err)
+	}
+
+	return hashed, nil
+}
+
+// Rotator rotates expiring Secrets and rolls the workloads that consume them.
+type Rotator struct {
+	Clientset    kubernetes.Interface
+	Generator    Generator
+	RotateBefore time.Duration
+}
+
+// NewRotator builds a Rotator with the given rotation threshold and generator.
+func NewRotator(clientset kubernetes.Interface, generator Generator, rotateBefore time.Duration) *Rotator {
+	return &Rotator{
+		Clientset:    clientset,
+		Generator:    generator,
+		RotateBefore: rotateBefore,
+	}
+}
+
+// ShouldRotate reports whether a Secret's expiry annotation falls within the
+// configured rotation threshold.
+func (r *Rotator) ShouldRotate(secret v1.Secret) (bool, time.Time, error) {
+	expiryRaw, ok := secret.Annotations[ExpiryAnnotationKey]
+	if !ok {
+		return false, time.Time{}, nil
+	}
+
+	expiry, err := time.Parse(time.RFC3339, expiryRaw)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("invalid %s annotation %q: %w", ExpiryAnnotationKey, expiryRaw, err)
+	}
+
+	return time.Until(expiry) <= r.RotateBefore, expiry, nil
+}
+
+// Rotate regenerates every key in the Secret's data and updates the Secret
+// with the new values and a refreshed expiry annotation. It does not roll the
+// workloads that consume it; RestartWorkloads does that.
+func (r *Rotator) Rotate(ctx context.Context, secret v1.Secret, ttl time.Duration) (*v1.Secret, error) {
+	updated := secret.DeepCopy()
+	if updated.Data == nil {
+		updated.Data = map[string][]byte{}
+	}
+
+	for key := range secret.Data {
+		value, err := r.Generator.Generate(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate new value for %s/%s[%s]: %w", secret.Namespace, secret.Name, key, err)
+		}
+		updated.Data[key] = value
+	}
+
+	if updated.Annotations == nil {
+		updated.Annotations = map[string]string{}
+	}
+	updated.Annotations[ExpiryAnnotationKey] = time.Now().Add(ttl).Format(time.RFC3339)
+
+	result, err := r.Clientset.CoreV1().Secrets(secret.Namespace).Update(ctx, updated, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+
+	return result, nil
+}
+
+// RestartWorkloads rolls every Deployment, DaemonSet and StatefulSet named
+// in the given sets according to strategy: RestartStrategyRolling (the
+// default when empty) patches a restartedAt annotation as `kubectl rollout
+// restart` does, RestartStrategyRecreate deletes their Pods directly for
+// immediate recreation, and RestartStrategyNone skips rolling entirely.
+func (r *Rotator) RestartWorkloads(ctx context.Context, namespace string, strategy rotav1alpha1.RestartStrategy, deployments, daemonSets, statefulSets map[string]bool) error {
+	if strategy == rotav1alpha1.RestartStrategyNone {
+		return nil
+	}
+
+	restartedAt := time.Now().Format(time.RFC3339)
+
+	for name := range deployments {
+		if err := r.restartOne(ctx, "Deployment", namespace, name, restartedAt, strategy); err != nil {
+			return fmt.Errorf("failed to restart deployment %s/%s: %w", namespace, name, err)
+		}
+	}
+
+	for name := range daemonSets {
+		if err := r.restartOne(ctx, "DaemonSet", namespace, name, restartedAt, strategy); err != nil {
+			return fmt.Errorf("failed to restart daemonset %s/%s: %w", namespace, name, err)
+		}
+	}
+
+	for name := range statefulSets {
+		if err := r.restartOne(ctx, "StatefulSet", namespace, name, restartedAt, strategy); err != nil {
+			return fmt.Errorf("failed to restart statefulset %s/%s: %w", namespace, name, err)
+		}
+	}
+
+	return nil
+}
+
+// restartOne rolls a single workload of the given kind, either by patching
+// its restartedAt annotation (rolling) or by deleting its Pods directly
+// (recreate).
+func (r *Rotator) restartOne(ctx context.Context, kind, namespace, name, restartedAt string, strategy rotav1alpha1.RestartStrategy) error {
+	var selector *metav1.LabelSelector
+	var patch func() error
+
+	switch kind {
+	case "Deployment":
+		obj, err := r.Clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		selector = obj.Spec.Selector
+		patch = func() error {
+			raw, err := restartPatch(restartedAt)
+			if err != nil {
+				return err
+			}
+			_, err = r.Clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, raw, metav1.PatchOptions{})
+			return err
+		}
+	case "DaemonSet":
+		obj, err := r.Clientset.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		selector = obj.Spec.Selector
+		patch = func() error {
+			raw, err := restartPatch(restartedAt)
+			if err != nil {
+				return err
+			}
+			_, err = r.Clientset.AppsV1().DaemonSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, raw, metav1.PatchOptions{})
+			return err
+		}
+	case "StatefulSet":
+		obj, err := r.Clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		selector = obj.Spec.Selector
+		patch = func() error {
+			raw, err := restartPatch(restartedAt)
+			if err != nil {
+				return err
+			}
+			_, err = r.Clientset.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, raw, metav1.PatchOptions{})
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported workload kind %q", kind)
+	}
+
+	var err error
+	if strategy == rotav1alpha1.RestartStrategyRecreate {
+		err = r.recreatePods(ctx, namespace, selector)
+	} else {
+		err = patch()
+	}
+	if err != nil {
+		return err
+	}
+
+	recordWorkloadRestart(kind, namespace, name)
+	return nil
+}
+
+// recreatePods deletes every Pod matching selector in namespace, forcing
+// their owning workload's controller to recreate them immediately rather
+// than waiting for a rolling update.
+func (r *Rotator) recreatePods(ctx context.Context, namespace string, selector *metav1.LabelSelector) error {
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return fmt.Errorf("invalid pod selector: %w", err)
+	}
+
+	pods, err := r.Clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: sel.String()})
+	if err != nil {
+		return fmt.Errorf("failed to list pods for recreate: %w", err)
+	}
+
+	for _, pod := range pods.Items {
+		if err := r.Clientset.CoreV1().Pods(namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil {
+			return fmt.Errorf("failed to delete pod %s/%s: %w", namespace, pod.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func restartPatch(restartedAt string) ([]byte, error) {
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{
+						RestartedAtAnnotationKey: restartedAt,
+					},
+				},
+			},
+		},
+	}
+
+	raw, err := json.Marshal(patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal restart patch: %w", err)
+	}
+
+	return raw, nil
+}
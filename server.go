@@ -0,0 +1,46 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/klog/v2"
+)
+
+// readinessChecker reports whether the controller is ready to serve, i.e.
+// its informer caches have synced.
+type readinessChecker interface {
+	Ready() bool
+}
+
+// newHealthServer builds an HTTP server exposing /metrics for Prometheus
+// scraping, plus /healthz and /readyz so the process can run as a proper
+// Kubernetes Deployment with liveness and readiness probes.
+func newHealthServer(addr string, readiness readinessChecker) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
+		if !readiness.Ready() {
+			http.Error(w, "informer caches not synced", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	return &http.Server{Addr: addr, Handler: mux}
+}
+
+// runHealthServer serves srv until it fails for a reason other than a clean
+// shutdown.
+func runHealthServer(srv *http.Server) {
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		klog.ErrorS(err, "Metrics/health server exited")
+	}
+}